@@ -1,6 +1,10 @@
 package main
 
 import (
+	"path/filepath"
+	"strings"
+
+	"dagger.io/dagger"
 	"github.com/grafana/grafana-build/pipelines"
 	"github.com/urfave/cli/v2"
 )
@@ -16,12 +20,48 @@ var TestBackendIntegration = &cli.Command{
 }
 
 var BuildBackend = &cli.Command{
-	Name:   "build",
-	Action: PipelineAction(pipelines.GrafanaBackendBuild),
+	Name: "build",
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:     "version",
 			Required: false,
+			EnvVars:  []string{"GRAFANA_BUILD_VERSION"},
+		},
+		&cli.StringSliceFlag{
+			Name:  "platform",
+			Usage: "Platform(s) to build for, as GOOS/GOARCH pairs (e.g. linux/amd64,linux/arm64,windows/amd64,darwin/arm64); repeatable or comma-separated",
+			Value: cli.NewStringSlice("linux/amd64"),
 		},
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Host directory that built binaries are exported to",
+			Value: "dist",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+
+		client, err := dagger.Connect(ctx)
+		if err != nil {
+			return err
+		}
+
+		args, err := PipelineArgsFromContext(c, client)
+		if err != nil {
+			return err
+		}
+
+		var platforms []string
+		for _, p := range c.StringSlice("platform") {
+			platforms = append(platforms, strings.Split(p, ",")...)
+		}
+
+		// Artifacts (and the manifest.json read by `promote`) are grouped
+		// under a per-build-id directory so multiple runs can coexist, and so
+		// `promote --build-id` can find builds produced by this command the
+		// same way it finds ones produced by `package`.
+		dir := filepath.Join(c.String("dir"), args.BuildID)
+
+		return pipelines.GrafanaBackendBuildMatrix(ctx, client, args, platforms, c.Int("parallelism"), dir)
 	},
 }
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Config represents the on-disk structure of a `--config` file (for example
+// `.grafana-build.yaml`), letting CI systems commit a repo-local build spec
+// instead of stringing together long command lines. Values here are only
+// used as a fallback: CLI flags and environment variables always win.
+type Config struct {
+	GrafanaRef    string `yaml:"grafana-ref"`
+	EnterpriseRef string `yaml:"enterprise-ref"`
+	Version       string `yaml:"version"`
+	BuildID       string `yaml:"build-id"`
+	Enterprise    *bool  `yaml:"enterprise"`
+
+	// Commands holds per-command overrides, keyed by the leaf command name
+	// as urfave/cli sees it (e.g. "build", not "backend build" — that's
+	// what c.Command.Name resolves to for a subcommand), applied on top of
+	// the top-level defaults above.
+	Commands map[string]map[string]string `yaml:"commands"`
+}
+
+// LoadConfig reads and parses the config file at path.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %q: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// CommandOverride returns the per-command override for key under the given
+// leaf command name (e.g. "build"), or "" if there isn't one.
+func (c *Config) CommandOverride(command, key string) string {
+	if c == nil {
+		return ""
+	}
+	return c.Commands[command][key]
+}
+
+// configString reads one of the well-known top-level config fields by name,
+// returning "" if cfg is nil.
+func configString(cfg *Config, field string) string {
+	if cfg == nil {
+		return ""
+	}
+	switch field {
+	case "grafana-ref":
+		return cfg.GrafanaRef
+	case "enterprise-ref":
+		return cfg.EnterpriseRef
+	case "version":
+		return cfg.Version
+	case "build-id":
+		return cfg.BuildID
+	default:
+		return ""
+	}
+}
+
+// resolveEnterprise applies the "CLI flag > env var > config file > default"
+// precedence to the enterprise flag. Unlike the string flags above, a bool
+// flag's zero value ("false") is indistinguishable from "not set", so an
+// explicit --enterprise=false has to be detected with c.IsSet to be able to
+// override a config file that sets `enterprise: true`.
+func resolveEnterprise(c *cli.Context, cfg *Config) bool {
+	if c.IsSet("enterprise") {
+		return c.Bool("enterprise")
+	}
+	if v := cfg.CommandOverride(c.Command.Name, "enterprise"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	if cfg != nil && cfg.Enterprise != nil {
+		return *cfg.Enterprise
+	}
+	return c.Bool("enterprise")
+}
+
+// coalesce returns the first non-empty string in vals.
+func coalesce(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
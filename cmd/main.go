@@ -21,26 +21,49 @@ var app = &cli.App{
 			Value:   false,
 		},
 		&cli.BoolFlag{
-			Name:  "enterprise",
-			Usage: "If set, attempt to clone and initialize Grafana Enterprise",
+			Name:    "enterprise",
+			Usage:   "If set, attempt to clone and initialize Grafana Enterprise",
+			EnvVars: []string{"GRAFANA_BUILD_ENTERPRISE"},
 		},
 		&cli.StringFlag{
 			Name:     "grafana-ref",
 			Required: false,
-			Value:    "main",
+			EnvVars:  []string{"GRAFANA_BUILD_GRAFANA_REF"},
 		},
 		&cli.StringFlag{
 			Name:     "enterprise-ref",
 			Required: false,
-			Value:    "main",
+			EnvVars:  []string{"GRAFANA_BUILD_ENTERPRISE_REF"},
 		},
 		&cli.StringFlag{
 			Name:     "github-token",
 			Required: false,
 		},
 		&cli.StringFlag{
-			Name:     "build-id",
-			Required: false,
+			Name:    "build-id",
+			EnvVars: []string{"GRAFANA_BUILD_BUILD_ID"},
+		},
+		&cli.StringFlag{
+			Name:  "config",
+			Usage: "Path to a YAML config file (e.g. .grafana-build.yaml) providing defaults for other flags",
+		},
+		&cli.StringFlag{
+			Name:  "enterprise-path",
+			Usage: "Path to a local, already-checked-out grafana-enterprise directory; if set, it's used instead of cloning",
+		},
+		&cli.StringFlag{
+			Name:  "version-strategy",
+			Usage: "How to resolve --version when it's not set: package-json, git-describe, env, or static",
+			Value: "package-json",
+		},
+		&cli.StringFlag{
+			Name:  "version-suffix",
+			Usage: "Suffix appended to the resolved version (e.g. -nightly.<build-id>)",
+		},
+		&cli.IntFlag{
+			Name:  "parallelism",
+			Usage: "Maximum number of platforms to build concurrently for matrix builds",
+			Value: 4,
 		},
 	},
 	Before: func(cctx *cli.Context) error {
@@ -48,8 +71,13 @@ var app = &cli.App{
 		if err != nil {
 			return fmt.Errorf("failed to find a GitHub access token: %w", err)
 		}
+		// A token is only needed to clone grafana-enterprise; don't make
+		// everyone authenticate just to run a plain OSS build.
 		if token == "" {
-			return fmt.Errorf("could not find a GitHub token in the environment")
+			if cctx.Bool("enterprise") && cctx.String("enterprise-path") == "" {
+				return fmt.Errorf("could not find a GitHub token in the environment, gh CLI, token file, or keychain")
+			}
+			return nil
 		}
 		return cctx.Set("github-token", token)
 	},
@@ -60,17 +88,29 @@ var app = &cli.App{
 			Subcommands: BackendCommands,
 		},
 		PackageCommand,
+		PromoteCommand,
 	},
 }
 
 func PipelineArgsFromContext(c *cli.Context, client *dagger.Client) (pipelines.PipelineArgs, error) {
+	var cfg *Config
+	if p := c.String("config"); p != "" {
+		loaded, err := LoadConfig(p)
+		if err != nil {
+			return pipelines.PipelineArgs{}, fmt.Errorf("failed to load config file: %w", err)
+		}
+		cfg = loaded
+	}
+
+	command := c.Command.Name
+
 	var (
 		verbose       = c.Bool("v")
-		version       = c.String("version")
-		ref           = c.String("grafana-ref")
-		enterprise    = c.Bool("enterprise")
-		enterpriseRef = c.String("enterprise-ref")
-		buildID       = c.String("build-id")
+		version       = coalesce(c.String("version"), cfg.CommandOverride(command, "version"), configString(cfg, "version"))
+		ref           = coalesce(c.String("grafana-ref"), cfg.CommandOverride(command, "grafana-ref"), configString(cfg, "grafana-ref"), "main")
+		enterprise    = resolveEnterprise(c, cfg)
+		enterpriseRef = coalesce(c.String("enterprise-ref"), cfg.CommandOverride(command, "enterprise-ref"), configString(cfg, "enterprise-ref"), "main")
+		buildID       = coalesce(c.String("build-id"), cfg.CommandOverride(command, "build-id"), configString(cfg, "build-id"))
 		src           *dagger.Directory
 	)
 
@@ -98,11 +138,27 @@ func PipelineArgsFromContext(c *cli.Context, client *dagger.Client) (pipelines.P
 			return pipelines.PipelineArgs{}, err
 		}
 
-		// If the 'enterprise global flag is set, then clone and initialize Grafana Enterprise as well.
+		// If the 'enterprise global flag is set, then clone (or use a locally
+		// provided directory for) and initialize Grafana Enterprise as well.
 		if enterprise {
-			enterpriseDir, err := containers.CloneWithGitHubToken(client, c.String("github-token"), "https://github.com/grafana/grafana-enterprise.git", enterpriseRef)
-			if err != nil {
-				return pipelines.PipelineArgs{}, err
+			var enterpriseDir *dagger.Directory
+
+			if enterprisePath := c.String("enterprise-path"); enterprisePath != "" {
+				ef, err := os.Stat(enterprisePath)
+				if err != nil {
+					return pipelines.PipelineArgs{}, err
+				}
+				if !ef.IsDir() {
+					return pipelines.PipelineArgs{}, errors.New("enterprise path provided is not a directory")
+				}
+
+				enterpriseDir = client.Host().Directory(enterprisePath)
+			} else {
+				d, err := containers.CloneWithGitHubToken(client, c.String("github-token"), "https://github.com/grafana/grafana-enterprise.git", enterpriseRef)
+				if err != nil {
+					return pipelines.PipelineArgs{}, err
+				}
+				enterpriseDir = d
 			}
 
 			srcDir = containers.InitializeEnterprise(client, srcDir, enterpriseDir)
@@ -121,8 +177,7 @@ func PipelineArgsFromContext(c *cli.Context, client *dagger.Client) (pipelines.P
 	}
 
 	if version == "" {
-		log.Println("Version not provided; getting version from package.json...")
-		v, err := containers.GetPackageJSONVersion(c.Context, client, src)
+		v, err := resolveVersion(c.Context, client, src, c.String("version-strategy"))
 		if err != nil {
 			return pipelines.PipelineArgs{}, err
 		}
@@ -131,6 +186,10 @@ func PipelineArgsFromContext(c *cli.Context, client *dagger.Client) (pipelines.P
 		log.Println("Got version", v)
 	}
 
+	if suffix := c.String("version-suffix"); suffix != "" {
+		version += suffix
+	}
+
 	return pipelines.PipelineArgs{
 		BuildID:    buildID,
 		Verbose:    verbose,
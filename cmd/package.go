@@ -0,0 +1,89 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"dagger.io/dagger"
+	"github.com/grafana/grafana-build/pipelines"
+	"github.com/urfave/cli/v2"
+)
+
+// PackageCommand builds the compiled backend and frontend into one or more
+// distributable artifact formats (tarball, deb, rpm, docker). Each format is
+// implemented as a pipelines.PackageFunc registered in
+// pipelines.PackageFormats, so new formats can be added without touching
+// this wiring.
+var PackageCommand = &cli.Command{
+	Name:  "package",
+	Usage: "Package the Grafana backend and frontend into one or more distributable artifacts",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "version",
+			Usage:   "Version to package; if empty, the version is detected the same way as other commands",
+			EnvVars: []string{"GRAFANA_BUILD_VERSION"},
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "Comma-separated list of formats to build (tar,deb,rpm,docker)",
+			Value: "tar",
+		},
+		&cli.StringFlag{
+			Name:  "dir",
+			Usage: "Host directory that built artifacts are exported to",
+			Value: "dist",
+		},
+		&cli.BoolFlag{
+			Name:  "sign",
+			Usage: "Sign tarballs and deb/rpm packages with GPG and docker images with cosign",
+		},
+		&cli.StringFlag{
+			Name:  "deb-section",
+			Usage: "Debian control 'Section' field for the deb package",
+			Value: "web",
+		},
+		&cli.StringFlag{
+			Name:  "deb-maintainer",
+			Usage: "Debian control 'Maintainer' field for the deb package",
+			Value: "Grafana Labs <engineering@grafana.com>",
+		},
+		&cli.StringFlag{
+			Name:  "rpm-license",
+			Usage: "RPM spec 'License' field for the rpm package",
+			Value: "AGPL-3.0-only",
+		},
+		&cli.StringFlag{
+			Name:  "docker-repo",
+			Usage: "Repository to publish the docker image to",
+			Value: "grafana/grafana",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+
+		client, err := dagger.Connect(ctx)
+		if err != nil {
+			return err
+		}
+
+		args, err := PipelineArgsFromContext(c, client)
+		if err != nil {
+			return err
+		}
+
+		formats := strings.Split(c.String("format"), ",")
+
+		opts := pipelines.PackageOptions{
+			DebSection:    c.String("deb-section"),
+			DebMaintainer: c.String("deb-maintainer"),
+			RPMLicense:    c.String("rpm-license"),
+			DockerRepo:    c.String("docker-repo"),
+		}
+
+		// Artifacts (and the manifest.json read by `promote`) are grouped
+		// under a per-build-id directory so multiple runs can coexist.
+		dir := filepath.Join(c.String("dir"), args.BuildID)
+
+		return pipelines.GrafanaPackage(ctx, client, args, formats, dir, c.Bool("sign"), opts)
+	},
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"dagger.io/dagger"
+	"github.com/grafana/grafana-build/pipelines"
+	"github.com/urfave/cli/v2"
+)
+
+// PromoteCommand republishes the artifacts produced by a previous
+// `build`/`package` run (e.g. staging -> prod, or a dev registry -> public
+// registry) without recompiling anything, using the manifest that run wrote
+// out.
+var PromoteCommand = &cli.Command{
+	Name:  "promote",
+	Usage: "Republish artifacts from a previous build/package run, without recompiling",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "manifest",
+			Usage: "Path to the manifest.json produced by a previous build/package run",
+		},
+		&cli.StringFlag{
+			Name:  "build-id",
+			Usage: "Build ID of a previous run; used to locate its manifest.json under --dist if --manifest isn't set",
+		},
+		&cli.StringFlag{
+			Name:  "dist",
+			Usage: "Directory that previous build/package runs wrote artifacts and manifests under",
+			Value: "dist",
+		},
+		&cli.StringFlag{
+			Name:  "dest",
+			Usage: "Destination directory for file-based artifacts",
+			Value: "promoted",
+		},
+		&cli.StringFlag{
+			Name:  "docker-repo",
+			Usage: "Destination repository for docker artifacts; docker artifacts are skipped if unset",
+		},
+	},
+	Action: func(c *cli.Context) error {
+		ctx := c.Context
+
+		manifestPath := c.String("manifest")
+		if manifestPath == "" {
+			buildID := c.String("build-id")
+			if buildID == "" {
+				return fmt.Errorf("either --manifest or --build-id is required")
+			}
+			manifestPath = fmt.Sprintf("%s/%s/manifest.json", c.String("dist"), buildID)
+		}
+
+		manifest, err := pipelines.ReadManifest(manifestPath)
+		if err != nil {
+			return err
+		}
+
+		client, err := dagger.Connect(ctx)
+		if err != nil {
+			return err
+		}
+
+		return pipelines.GrafanaPromote(ctx, client, pipelines.PromoteArgs{
+			Manifest:   manifest,
+			Dest:       c.String("dest"),
+			DockerRepo: c.String("docker-repo"),
+		})
+	},
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"github.com/zalando/go-keyring"
+)
+
+const keyringService = "grafana-build"
+
+// lookupGitHubToken tries, in order, the --github-token flag, the
+// GITHUB_TOKEN/GH_TOKEN environment variables, `gh auth token` (if gh is on
+// PATH), a token file at ~/.config/grafana-build/token, and the OS keychain.
+// It returns "" (with no error) if none of those sources have a token.
+func lookupGitHubToken(c *cli.Context) (string, error) {
+	if token := c.String("github-token"); token != "" {
+		return token, nil
+	}
+
+	for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			return token, nil
+		}
+	}
+
+	if token, err := tokenFromGHCLI(); err == nil && token != "" {
+		return token, nil
+	}
+
+	if token, err := tokenFromFile(); err == nil && token != "" {
+		return token, nil
+	}
+
+	if token, err := keyring.Get(keyringService, "github-token"); err == nil && token != "" {
+		return token, nil
+	}
+
+	return "", nil
+}
+
+// tokenFromGHCLI shells out to `gh auth token`, which prints the token that
+// the gh CLI is already authenticated with.
+func tokenFromGHCLI() (string, error) {
+	path, err := exec.LookPath("gh")
+	if err != nil {
+		return "", err
+	}
+
+	out, err := exec.Command(path, "auth", "token").Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// tokenFromFile reads a token from ~/.config/grafana-build/token.
+func tokenFromFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	b, err := os.ReadFile(filepath.Join(home, ".config", "grafana-build", "token"))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"dagger.io/dagger"
+	"github.com/grafana/grafana-build/containers"
+)
+
+// resolveVersion determines the Grafana version to build when --version
+// wasn't provided, using the given strategy.
+func resolveVersion(ctx context.Context, client *dagger.Client, src *dagger.Directory, strategy string) (string, error) {
+	switch strategy {
+	case "", "package-json":
+		return containers.GetPackageJSONVersion(ctx, client, src)
+	case "git-describe":
+		return containers.GitDescribeVersion(ctx, client, src)
+	case "env":
+		v := os.Getenv("GRAFANA_BUILD_VERSION")
+		if v == "" {
+			return "", fmt.Errorf("version-strategy=env but GRAFANA_BUILD_VERSION is not set")
+		}
+		return v, nil
+	case "static":
+		return "", fmt.Errorf("version-strategy=static requires --version to be set")
+	default:
+		return "", fmt.Errorf("unknown version-strategy %q", strategy)
+	}
+}
@@ -0,0 +1,53 @@
+package containers
+
+import (
+	"dagger.io/dagger"
+)
+
+// gitCredentialScript is installed as a git credential helper so the token
+// is supplied to git directly, never interpolated into a shell command.
+const gitCredentialScript = `#!/bin/sh
+echo "username=x-access-token"
+echo "password=$GITHUB_TOKEN"
+`
+
+// Clone clones the git repository at url, checked out to ref, and returns
+// the resulting directory. The clone is done with a real `git clone` (not
+// dagger's `Git().Tree()`, which returns the working tree only) so that
+// callers like GitDescribeVersion have `.git` metadata to work with.
+func Clone(client *dagger.Client, url, ref string) (*dagger.Directory, error) {
+	container := client.Container().
+		From("alpine/git").
+		WithExec([]string{"clone", "--branch", ref, "--single-branch", url, "/src"})
+
+	return container.Directory("/src"), nil
+}
+
+// CloneWithGitHubToken clones the git repository at url using token for
+// authentication, checked out to ref, and returns the resulting directory.
+// This is used for private repositories such as grafana-enterprise.
+//
+// ref and url are passed to git as plain argv elements (never through a
+// shell), and the token is handed to git via a credential helper rather
+// than string-interpolated into a command, so neither can be abused to
+// inject shell commands or leak the token.
+func CloneWithGitHubToken(client *dagger.Client, token, url, ref string) (*dagger.Directory, error) {
+	tokenSecret := client.SetSecret("github-token", token)
+
+	container := client.Container().
+		From("alpine/git").
+		WithSecretVariable("GITHUB_TOKEN", tokenSecret).
+		WithNewFile("/usr/local/bin/git-credential-github", gitCredentialScript, dagger.ContainerWithNewFileOpts{
+			Permissions: 0o755,
+		}).
+		WithExec([]string{"config", "--global", "credential.helper", "/usr/local/bin/git-credential-github"}).
+		WithExec([]string{"clone", "--branch", ref, "--single-branch", url, "/src"})
+
+	return container.Directory("/src"), nil
+}
+
+// InitializeEnterprise merges the grafana-enterprise source directory into
+// the Grafana source directory, returning the combined directory.
+func InitializeEnterprise(client *dagger.Client, grafana, enterprise *dagger.Directory) *dagger.Directory {
+	return grafana.WithDirectory("enterprise", enterprise)
+}
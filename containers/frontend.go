@@ -0,0 +1,25 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+
+	"dagger.io/dagger"
+)
+
+// BuildFrontend installs dependencies and runs the production webpack build,
+// returning the resulting `public/build` directory.
+func BuildFrontend(ctx context.Context, client *dagger.Client, src *dagger.Directory) (*dagger.Directory, error) {
+	container := client.Container().
+		From("node:20").
+		WithMountedDirectory("/src", src).
+		WithWorkdir("/src").
+		WithExec([]string{"yarn", "install", "--immutable"}).
+		WithExec([]string{"yarn", "build"})
+
+	if _, err := container.Sync(ctx); err != nil {
+		return nil, fmt.Errorf("failed to build frontend: %w", err)
+	}
+
+	return container.Directory("public/build"), nil
+}
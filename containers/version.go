@@ -0,0 +1,29 @@
+package containers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"dagger.io/dagger"
+)
+
+type packageJSON struct {
+	Version string `json:"version"`
+}
+
+// GetPackageJSONVersion reads the `version` field out of the package.json
+// file at the root of src.
+func GetPackageJSONVersion(ctx context.Context, client *dagger.Client, src *dagger.Directory) (string, error) {
+	contents, err := src.File("package.json").Contents(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read package.json: %w", err)
+	}
+
+	var p packageJSON
+	if err := json.Unmarshal([]byte(contents), &p); err != nil {
+		return "", fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	return p.Version, nil
+}
@@ -0,0 +1,52 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"dagger.io/dagger"
+)
+
+// GitDescribeVersion derives a semver-ish version from the nearest git tag
+// and short SHA of src (e.g. "10.1.0-pre+abc1234"), using `git describe`
+// inside a container rather than shelling out on the host. src must contain
+// `.git` metadata (as produced by Clone) rather than just a working tree.
+func GitDescribeVersion(ctx context.Context, client *dagger.Client, src *dagger.Directory) (string, error) {
+	out, err := client.Container().
+		From("alpine/git").
+		WithMountedDirectory("/src", src).
+		WithWorkdir("/src").
+		WithExec([]string{"describe", "--tags", "--long", "--always"}).
+		Stdout(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to run git describe: %w", err)
+	}
+
+	return parseGitDescribe(strings.TrimSpace(out)), nil
+}
+
+// parseGitDescribe turns `git describe --tags --long --always` output
+// (e.g. "v10.1.0-4-gabc1234") into a semver-ish version
+// (e.g. "10.1.0-pre+abc1234"). --long always appends a "-N-gSHA" suffix once
+// any tag exists, even at an exact tag match (N == "0"), in which case the
+// bare tag is returned instead. Repos with no tags at all (--always falling
+// back to a bare SHA) are returned as-is, with any leading "v" stripped.
+func parseGitDescribe(describe string) string {
+	tag := strings.TrimPrefix(describe, "v")
+
+	parts := strings.Split(tag, "-")
+	if len(parts) < 3 {
+		return tag
+	}
+
+	commits := parts[len(parts)-2]
+	base := strings.Join(parts[:len(parts)-2], "-")
+
+	if commits == "0" {
+		return base
+	}
+
+	sha := strings.TrimPrefix(parts[len(parts)-1], "g")
+	return fmt.Sprintf("%s-pre+%s", base, sha)
+}
@@ -0,0 +1,125 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"dagger.io/dagger"
+	"golang.org/x/sync/errgroup"
+)
+
+// GrafanaBackendBuild builds the Grafana backend binaries without setting
+// GOOS/GOARCH, so for the build container's own platform, and writes them,
+// along with manifest.json, to dir. dir should
+// follow the same <dist>/<build-id> convention as GrafanaPackage so that
+// `promote --build-id` can find the manifest regardless of which command
+// produced it.
+func GrafanaBackendBuild(ctx context.Context, client *dagger.Client, args PipelineArgs, dir string) error {
+	_, err := buildBackendPlatform(ctx, client, args, "", dir)
+	if err != nil {
+		return err
+	}
+
+	return WriteManifest(dir, Manifest{
+		BuildID: args.BuildID,
+		Version: args.Version,
+		Artifacts: []Artifact{
+			{Format: "binary", Path: filepath.Join(dir, "grafana-server")},
+		},
+	})
+}
+
+// GrafanaBackendBuildMatrix builds the Grafana backend for each of platforms
+// (GOOS/GOARCH pairs, e.g. "linux/amd64") concurrently, up to parallelism
+// builds at once, writing each platform's binaries to its own subdirectory of
+// dir (e.g. <dir>/linux_amd64/grafana-server) along with manifest.json. dir
+// should follow the same <dist>/<build-id> convention as GrafanaPackage so
+// that `promote --build-id` can find the manifest regardless of which
+// command produced it.
+func GrafanaBackendBuildMatrix(ctx context.Context, client *dagger.Client, args PipelineArgs, platforms []string, parallelism int, dir string) error {
+	g, ctx := errgroup.WithContext(ctx)
+	if parallelism > 0 {
+		g.SetLimit(parallelism)
+	}
+
+	artifacts := make([]Artifact, len(platforms))
+	for i, platform := range platforms {
+		i, platform := i, platform
+		g.Go(func() error {
+			platformDir := filepath.Join(dir, strings.ReplaceAll(platform, "/", "_"))
+
+			binary, err := buildBackendPlatform(ctx, client, args, platform, platformDir)
+			if err != nil {
+				return fmt.Errorf("platform %q: %w", platform, err)
+			}
+
+			artifacts[i] = Artifact{Format: "binary:" + platform, Path: binary}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	return WriteManifest(dir, Manifest{
+		BuildID:   args.BuildID,
+		Version:   args.Version,
+		Artifacts: artifacts,
+	})
+}
+
+// buildBackendPlatform builds the backend, cross-compiling for platform (a
+// "GOOS/GOARCH" pair) if it's non-empty, and exports the result to dir. It
+// returns the path to the grafana-server binary it produced.
+func buildBackendPlatform(ctx context.Context, client *dagger.Client, args PipelineArgs, platform, dir string) (string, error) {
+	container := client.Container().
+		From("golang:1.21").
+		WithMountedDirectory("/src", args.Grafana).
+		WithWorkdir("/src")
+
+	if platform != "" {
+		goos, goarch, ok := strings.Cut(platform, "/")
+		if !ok {
+			return "", fmt.Errorf("invalid platform %q, expected GOOS/GOARCH", platform)
+		}
+		container = container.
+			WithEnvVariable("GOOS", goos).
+			WithEnvVariable("GOARCH", goarch)
+	}
+
+	container = container.WithExec([]string{"go", "build", "-o", "bin/grafana-server", "./pkg/cmd/grafana-server"})
+
+	if _, err := container.Directory("bin").Export(ctx, dir); err != nil {
+		return "", fmt.Errorf("failed to build grafana backend: %w", err)
+	}
+
+	return filepath.Join(dir, "grafana-server"), nil
+}
+
+// GrafanaBackendTests runs the Grafana backend unit test suite.
+func GrafanaBackendTests(ctx context.Context, client *dagger.Client, args PipelineArgs) error {
+	_, err := client.Container().
+		From("golang:1.21").
+		WithMountedDirectory("/src", args.Grafana).
+		WithWorkdir("/src").
+		WithExec([]string{"go", "test", "./pkg/..."}).
+		Sync(ctx)
+
+	return err
+}
+
+// GrafanaBackendTestIntegration runs the Grafana backend integration test
+// suite.
+func GrafanaBackendTestIntegration(ctx context.Context, client *dagger.Client, args PipelineArgs) error {
+	_, err := client.Container().
+		From("golang:1.21").
+		WithMountedDirectory("/src", args.Grafana).
+		WithWorkdir("/src").
+		WithExec([]string{"go", "test", "-tags", "integration", "./pkg/..."}).
+		Sync(ctx)
+
+	return err
+}
@@ -0,0 +1,57 @@
+package pipelines
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Artifact describes a single built artifact recorded in a Manifest.
+type Artifact struct {
+	Format string `json:"format"`
+	Path   string `json:"path"`
+	Digest string `json:"digest,omitempty"`
+
+	// Signature is the path to a detached signature for Path (e.g. the GPG
+	// .asc file produced when packaging with --sign), if one was produced.
+	Signature string `json:"signature,omitempty"`
+}
+
+// Manifest is the machine-readable record of everything produced by a single
+// build/package run, written to <dir>/manifest.json. The `promote` command
+// reads it to know which artifacts to republish.
+type Manifest struct {
+	BuildID   string     `json:"buildId"`
+	Version   string     `json:"version"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// WriteManifest writes m as JSON to <dir>/manifest.json.
+func WriteManifest(dir string, m Manifest) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+
+	return os.WriteFile(filepath.Join(dir, "manifest.json"), b, 0o644)
+}
+
+// ReadManifest reads a manifest previously written by WriteManifest.
+func ReadManifest(path string) (Manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read manifest %q: %w", path, err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse manifest %q: %w", path, err)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,103 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	"dagger.io/dagger"
+	"github.com/grafana/grafana-build/containers"
+)
+
+// PackageFunc builds a single package format (tarball, deb, rpm, docker, ...)
+// from the already-compiled backend and frontend, writing the resulting
+// artifact under dir and returning it for inclusion in the build manifest.
+// Registering a new format means adding a PackageFunc to PackageFormats; the
+// CLI wiring in cmd doesn't need to change.
+type PackageFunc func(ctx context.Context, client *dagger.Client, args PackageArgs) (Artifact, error)
+
+// PackageArgs are the arguments shared by every PackageFunc.
+type PackageArgs struct {
+	PipelineArgs
+
+	Backend  *dagger.Directory
+	Frontend *dagger.Directory
+
+	// Dir is the host directory that produced artifacts should be exported
+	// to.
+	Dir string
+
+	// Sign, when true, instructs the PackageFunc to sign its output: GPG for
+	// tar/deb/rpm, cosign for docker.
+	Sign bool
+
+	// Per-format options; only relevant to the PackageFunc for that format.
+	DebSection    string
+	DebMaintainer string
+	RPMLicense    string
+	DockerRepo    string
+}
+
+// PackageOptions holds the per-format flags exposed by the `package`
+// command.
+type PackageOptions struct {
+	DebSection    string
+	DebMaintainer string
+	RPMLicense    string
+	DockerRepo    string
+}
+
+// PackageFormats maps a `--format` value to the function that produces it.
+var PackageFormats = map[string]PackageFunc{
+	"tar":    PackageTarball,
+	"deb":    PackageDeb,
+	"rpm":    PackageRPM,
+	"docker": PackageDocker,
+}
+
+// GrafanaPackage builds the Grafana backend and frontend, then invokes the
+// PackageFunc registered for each of formats, writing artifacts (and
+// manifest.json) to dir.
+func GrafanaPackage(ctx context.Context, client *dagger.Client, args PipelineArgs, formats []string, dir string, sign bool, opts PackageOptions) error {
+	if _, err := buildBackendPlatform(ctx, client, args, "", "bin"); err != nil {
+		return fmt.Errorf("failed to build backend: %w", err)
+	}
+
+	backend := client.Host().Directory("bin")
+	frontend, err := containers.BuildFrontend(ctx, client, args.Grafana)
+	if err != nil {
+		return fmt.Errorf("failed to build frontend: %w", err)
+	}
+
+	pArgs := PackageArgs{
+		PipelineArgs:  args,
+		Backend:       backend,
+		Frontend:      frontend,
+		Dir:           dir,
+		Sign:          sign,
+		DebSection:    opts.DebSection,
+		DebMaintainer: opts.DebMaintainer,
+		RPMLicense:    opts.RPMLicense,
+		DockerRepo:    opts.DockerRepo,
+	}
+
+	manifest := Manifest{
+		BuildID: args.BuildID,
+		Version: args.Version,
+	}
+
+	for _, format := range formats {
+		fn, ok := PackageFormats[format]
+		if !ok {
+			return fmt.Errorf("unknown package format %q", format)
+		}
+
+		artifact, err := fn(ctx, client, pArgs)
+		if err != nil {
+			return fmt.Errorf("failed to build %q package: %w", format, err)
+		}
+
+		manifest.Artifacts = append(manifest.Artifacts, artifact)
+	}
+
+	return WriteManifest(dir, manifest)
+}
@@ -0,0 +1,66 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	"dagger.io/dagger"
+)
+
+// PackageDeb packages the backend and frontend into a .deb package using
+// dpkg-deb, then signs it with GPG if args.Sign is set.
+func PackageDeb(ctx context.Context, client *dagger.Client, args PackageArgs) (Artifact, error) {
+	name := fmt.Sprintf("grafana_%s_amd64.deb", args.Version)
+	path := fmt.Sprintf("%s/%s", args.Dir, name)
+
+	section := args.DebSection
+	if section == "" {
+		section = "web"
+	}
+
+	maintainer := args.DebMaintainer
+	if maintainer == "" {
+		maintainer = "Grafana Labs <engineering@grafana.com>"
+	}
+
+	control := fmt.Sprintf(`Package: grafana
+Version: %s
+Architecture: amd64
+Maintainer: %s
+Section: %s
+Priority: optional
+Description: Grafana
+ Grafana is an open-source platform for monitoring and observability.
+`, args.Version, maintainer, section)
+
+	container := client.Container().
+		From("debian:bookworm").
+		WithExec([]string{"apt-get", "update"}).
+		WithExec([]string{"apt-get", "install", "-y", "dpkg-dev"}).
+		WithDirectory("/pkg/grafana/usr/sbin", args.Backend).
+		WithDirectory("/pkg/grafana/usr/share/grafana/public", args.Frontend).
+		WithNewFile("/pkg/grafana/DEBIAN/control", control).
+		WithExec([]string{"dpkg-deb", "--build", "/pkg/grafana", "/" + name})
+
+	artifact := Artifact{Format: "deb", Path: path}
+
+	if args.Sign {
+		var err error
+		container, err = signWithGPG(ctx, client, container.WithExec([]string{"apt-get", "install", "-y", "gnupg"}), "/"+name)
+		if err != nil {
+			return Artifact{}, fmt.Errorf("failed to sign deb package: %w", err)
+		}
+
+		sigPath := path + ".asc"
+		if _, err := container.File("/" + name + ".asc").Export(ctx, sigPath); err != nil {
+			return Artifact{}, fmt.Errorf("failed to export deb package signature: %w", err)
+		}
+		artifact.Signature = sigPath
+	}
+
+	if _, err := container.File("/" + name).Export(ctx, path); err != nil {
+		return Artifact{}, fmt.Errorf("failed to export deb package: %w", err)
+	}
+
+	return artifact, nil
+}
@@ -0,0 +1,37 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	"dagger.io/dagger"
+)
+
+// PackageDocker builds a Grafana docker image from the backend and frontend
+// and publishes it, signing it with cosign if args.Sign is set.
+func PackageDocker(ctx context.Context, client *dagger.Client, args PackageArgs) (Artifact, error) {
+	repo := args.DockerRepo
+	if repo == "" {
+		repo = "grafana/grafana"
+	}
+	ref := fmt.Sprintf("%s:%s", repo, args.Version)
+
+	image := client.Container().
+		From("alpine").
+		WithDirectory("/usr/share/grafana/bin", args.Backend).
+		WithDirectory("/usr/share/grafana/public", args.Frontend).
+		WithEntrypoint([]string{"/usr/share/grafana/bin/grafana-server"})
+
+	digest, err := image.Publish(ctx, ref)
+	if err != nil {
+		return Artifact{}, fmt.Errorf("failed to publish docker image: %w", err)
+	}
+
+	if args.Sign {
+		if err := signWithCosign(ctx, client, digest); err != nil {
+			return Artifact{}, fmt.Errorf("failed to sign docker image: %w", err)
+		}
+	}
+
+	return Artifact{Format: "docker", Path: ref, Digest: digest}, nil
+}
@@ -0,0 +1,78 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	"dagger.io/dagger"
+)
+
+// PackageRPM packages the backend and frontend into an .rpm package using
+// rpmbuild, then signs it with GPG if args.Sign is set.
+func PackageRPM(ctx context.Context, client *dagger.Client, args PackageArgs) (Artifact, error) {
+	name := fmt.Sprintf("grafana-%s-1.x86_64.rpm", args.Version)
+	path := fmt.Sprintf("%s/%s", args.Dir, name)
+
+	license := args.RPMLicense
+	if license == "" {
+		license = "AGPL-3.0-only"
+	}
+
+	const topdir = "/root/rpmbuild"
+	buildroot := topdir + "/BUILDROOT/grafana"
+	rpmPath := fmt.Sprintf("%s/RPMS/x86_64/%s", topdir, name)
+
+	// The binaries are already staged under buildroot, so the spec only
+	// needs a %files section describing what to package; there's no
+	// %build/%install step to run.
+	spec := fmt.Sprintf(`Name: grafana
+Version: %s
+Release: 1
+License: %s
+Summary: Grafana
+BuildArch: x86_64
+
+%%description
+Grafana is an open-source platform for monitoring and observability.
+
+%%files
+/usr/sbin/grafana-server
+/usr/share/grafana/public
+`, args.Version, license)
+
+	container := client.Container().
+		From("fedora:39").
+		WithExec([]string{"dnf", "install", "-y", "rpm-build"}).
+		WithDirectory(buildroot+"/usr/sbin", args.Backend).
+		WithDirectory(buildroot+"/usr/share/grafana/public", args.Frontend).
+		WithNewFile(topdir+"/SPECS/grafana.spec", spec).
+		WithWorkdir(topdir + "/SPECS").
+		WithExec([]string{
+			"rpmbuild", "-bb",
+			"--define", "_topdir " + topdir,
+			"--buildroot", buildroot,
+			"grafana.spec",
+		})
+
+	artifact := Artifact{Format: "rpm", Path: path}
+
+	if args.Sign {
+		var err error
+		container, err = signWithGPG(ctx, client, container.WithExec([]string{"dnf", "install", "-y", "gnupg2"}), rpmPath)
+		if err != nil {
+			return Artifact{}, fmt.Errorf("failed to sign rpm package: %w", err)
+		}
+
+		sigPath := path + ".asc"
+		if _, err := container.File(rpmPath + ".asc").Export(ctx, sigPath); err != nil {
+			return Artifact{}, fmt.Errorf("failed to export rpm package signature: %w", err)
+		}
+		artifact.Signature = sigPath
+	}
+
+	if _, err := container.File(rpmPath).Export(ctx, path); err != nil {
+		return Artifact{}, fmt.Errorf("failed to export rpm package: %w", err)
+	}
+
+	return artifact, nil
+}
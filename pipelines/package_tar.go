@@ -0,0 +1,43 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+
+	"dagger.io/dagger"
+)
+
+// PackageTarball packages the backend and frontend into a gzipped tarball.
+func PackageTarball(ctx context.Context, client *dagger.Client, args PackageArgs) (Artifact, error) {
+	name := fmt.Sprintf("grafana-%s.tar.gz", args.Version)
+	path := fmt.Sprintf("%s/%s", args.Dir, name)
+
+	container := client.Container().
+		From("alpine").
+		WithDirectory("/pkg/bin", args.Backend).
+		WithDirectory("/pkg/public", args.Frontend).
+		WithWorkdir("/pkg").
+		WithExec([]string{"tar", "-czf", "/" + name, "."})
+
+	artifact := Artifact{Format: "tar", Path: path}
+
+	if args.Sign {
+		var err error
+		container, err = signWithGPG(ctx, client, container.WithExec([]string{"apk", "add", "--no-cache", "gnupg"}), "/"+name)
+		if err != nil {
+			return Artifact{}, fmt.Errorf("failed to sign tarball: %w", err)
+		}
+
+		sigPath := path + ".asc"
+		if _, err := container.File("/" + name + ".asc").Export(ctx, sigPath); err != nil {
+			return Artifact{}, fmt.Errorf("failed to export tarball signature: %w", err)
+		}
+		artifact.Signature = sigPath
+	}
+
+	if _, err := container.File("/" + name).Export(ctx, path); err != nil {
+		return Artifact{}, fmt.Errorf("failed to export tarball: %w", err)
+	}
+
+	return artifact, nil
+}
@@ -0,0 +1,36 @@
+package pipelines
+
+import (
+	"context"
+
+	"dagger.io/dagger"
+	"github.com/urfave/cli/v2"
+)
+
+// PipelineArgs are the arguments used by nearly every pipeline function;
+// they represent the common set of inputs needed to check out, build, and
+// test Grafana, regardless of which specific pipeline is being run.
+type PipelineArgs struct {
+	// BuildID uniquely identifies this invocation; it's used to group the
+	// artifacts produced by a single `grafana-build` run.
+	BuildID string
+
+	Verbose bool
+	Version string
+
+	// Enterprise is the resolved --enterprise setting. The grafana-enterprise
+	// source is merged into Grafana only when Grafana was cloned; a local
+	// Grafana path is used as it is, whatever this is set to.
+	Enterprise bool
+
+	Context *cli.Context
+
+	// Grafana is the directory containing the Grafana source code (and, if
+	// Enterprise is true and Grafana was cloned, the grafana-enterprise
+	// source merged into it).
+	Grafana *dagger.Directory
+}
+
+// PipelineFunc is a function that uses the given dagger client and
+// PipelineArgs to run a pipeline (build, test, package, ...).
+type PipelineFunc func(ctx context.Context, client *dagger.Client, args PipelineArgs) error
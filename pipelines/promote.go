@@ -0,0 +1,89 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dagger.io/dagger"
+)
+
+// PromoteArgs configures a GrafanaPromote run.
+type PromoteArgs struct {
+	// Manifest is the build manifest to republish artifacts from.
+	Manifest Manifest
+
+	// Dest is the destination directory that file-based artifacts (tar,
+	// deb, rpm, binary) are copied into; per-platform binaries go into a
+	// subdirectory named after their platform (e.g. <Dest>/linux_amd64).
+	Dest string
+
+	// DockerRepo, if set, is the repository that docker artifacts are
+	// re-tagged and pushed to; docker artifacts are skipped if it's empty.
+	DockerRepo string
+}
+
+// GrafanaPromote republishes the artifacts recorded in a manifest produced
+// by a previous build/package run to a new destination, without
+// recompiling anything.
+func GrafanaPromote(ctx context.Context, client *dagger.Client, args PromoteArgs) error {
+	for _, artifact := range args.Manifest.Artifacts {
+		if artifact.Format == "docker" {
+			if args.DockerRepo == "" {
+				continue
+			}
+			if err := promoteDockerImage(ctx, client, artifact, args.DockerRepo, args.Manifest.Version); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Matrix builds produce one grafana-server per platform, so each
+		// platform's binary goes to its own subdirectory, as in the build output.
+		dest := args.Dest
+		if strings.HasPrefix(artifact.Format, "binary:") {
+			dest = filepath.Join(dest, strings.ReplaceAll(strings.TrimPrefix(artifact.Format, "binary:"), "/", "_"))
+		}
+
+		if err := promoteFile(ctx, client, artifact, dest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// promoteFile copies a file-based artifact into dest.
+func promoteFile(ctx context.Context, client *dagger.Client, artifact Artifact, dest string) error {
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	f := client.Host().Directory(filepath.Dir(artifact.Path)).File(filepath.Base(artifact.Path))
+	if _, err := f.Export(ctx, filepath.Join(dest, filepath.Base(artifact.Path))); err != nil {
+		return fmt.Errorf("failed to promote %q: %w", artifact.Path, err)
+	}
+
+	if artifact.Signature != "" {
+		sig := client.Host().Directory(filepath.Dir(artifact.Signature)).File(filepath.Base(artifact.Signature))
+		if _, err := sig.Export(ctx, filepath.Join(dest, filepath.Base(artifact.Signature))); err != nil {
+			return fmt.Errorf("failed to promote signature %q: %w", artifact.Signature, err)
+		}
+	}
+
+	return nil
+}
+
+// promoteDockerImage re-tags and republishes a docker image to repo without
+// rebuilding it.
+func promoteDockerImage(ctx context.Context, client *dagger.Client, artifact Artifact, repo, version string) error {
+	ref := fmt.Sprintf("%s:%s", repo, version)
+
+	if _, err := client.Container().From(artifact.Digest).Publish(ctx, ref); err != nil {
+		return fmt.Errorf("failed to promote docker image to %q: %w", ref, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package pipelines
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"dagger.io/dagger"
+)
+
+// signWithGPG imports GPG_PRIVATE_KEY into the container's keyring and uses
+// it to detach-sign the file at path, producing path+".asc". GPG_PASSPHRASE
+// is used to unlock the key if it's passphrase-protected.
+func signWithGPG(ctx context.Context, client *dagger.Client, container *dagger.Container, path string) (*dagger.Container, error) {
+	key := os.Getenv("GPG_PRIVATE_KEY")
+	if key == "" {
+		return nil, fmt.Errorf("GPG_PRIVATE_KEY must be set to sign packages")
+	}
+
+	keySecret := client.SetSecret("gpg-private-key", key)
+	passphraseSecret := client.SetSecret("gpg-passphrase", os.Getenv("GPG_PASSPHRASE"))
+
+	signed := container.
+		WithSecretVariable("GPG_PRIVATE_KEY", keySecret).
+		WithSecretVariable("GPG_PASSPHRASE", passphraseSecret).
+		WithExec([]string{"sh", "-c", `echo "$GPG_PRIVATE_KEY" | gpg --batch --import`}).
+		WithExec([]string{"sh", "-c", fmt.Sprintf(`gpg --batch --yes --pinentry-mode loopback --passphrase "$GPG_PASSPHRASE" --detach-sign --armor %s`, path)})
+
+	if _, err := signed.Sync(ctx); err != nil {
+		return nil, fmt.Errorf("gpg sign failed: %w", err)
+	}
+
+	return signed, nil
+}
+
+// signWithCosign signs the published docker image digest using cosign and
+// the key material in COSIGN_KEY (password-protected by COSIGN_PASSWORD, if
+// set).
+func signWithCosign(ctx context.Context, client *dagger.Client, digest string) error {
+	key := os.Getenv("COSIGN_KEY")
+	if key == "" {
+		return fmt.Errorf("COSIGN_KEY must be set to sign docker images")
+	}
+
+	keySecret := client.SetSecret("cosign-key", key)
+	passwordSecret := client.SetSecret("cosign-password", os.Getenv("COSIGN_PASSWORD"))
+
+	_, err := client.Container().
+		From("gcr.io/projectsigstore/cosign").
+		WithSecretVariable("COSIGN_KEY", keySecret).
+		WithSecretVariable("COSIGN_PASSWORD", passwordSecret).
+		WithExec([]string{"cosign", "sign", "--yes", "--key", "env://COSIGN_KEY", digest}).
+		Sync(ctx)
+	if err != nil {
+		return fmt.Errorf("cosign sign failed: %w", err)
+	}
+
+	return nil
+}